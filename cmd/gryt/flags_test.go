@@ -0,0 +1,81 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestParseGlobalFlagsDefaults(t *testing.T) {
+	gf, rest := parseGlobalFlags([]string{"run", "foo.py"})
+	if gf.python != "" || gf.dryRun || gf.verbose {
+		t.Fatalf("parseGlobalFlags: expected zero-value flags, got %+v", gf)
+	}
+	if gf.shutdownGrace != defaultShutdownGrace {
+		t.Fatalf("parseGlobalFlags: shutdownGrace = %v, want default %v", gf.shutdownGrace, defaultShutdownGrace)
+	}
+	if want := []string{"run", "foo.py"}; !reflect.DeepEqual(rest, want) {
+		t.Fatalf("parseGlobalFlags: rest = %v, want %v", rest, want)
+	}
+}
+
+func TestParseGlobalFlagsPython(t *testing.T) {
+	gf, rest := parseGlobalFlags([]string{"--python", "/usr/bin/python3", "run", "foo.py"})
+	if gf.python != "/usr/bin/python3" {
+		t.Fatalf("parseGlobalFlags: python = %q, want %q", gf.python, "/usr/bin/python3")
+	}
+	if want := []string{"run", "foo.py"}; !reflect.DeepEqual(rest, want) {
+		t.Fatalf("parseGlobalFlags: rest = %v, want %v", rest, want)
+	}
+}
+
+func TestParseGlobalFlagsDryRunAndVerbose(t *testing.T) {
+	gf, rest := parseGlobalFlags([]string{"-n", "-v", "run", "foo.py"})
+	if !gf.dryRun {
+		t.Fatalf("parseGlobalFlags: dryRun = false, want true")
+	}
+	if !gf.verbose {
+		t.Fatalf("parseGlobalFlags: verbose = false, want true")
+	}
+	if want := []string{"run", "foo.py"}; !reflect.DeepEqual(rest, want) {
+		t.Fatalf("parseGlobalFlags: rest = %v, want %v", rest, want)
+	}
+}
+
+// TestParseGlobalFlagsStopsAtFirstPositional guards against a regression
+// where a flag appearing after the subcommand (e.g. a `-v` meant for `run`
+// itself) was mistaken for a global flag and swallowed instead of being left
+// in rest for the subcommand to parse.
+func TestParseGlobalFlagsStopsAtFirstPositional(t *testing.T) {
+	gf, rest := parseGlobalFlags([]string{"run", "-v", "foo.py"})
+	if gf.verbose {
+		t.Fatalf("parseGlobalFlags: verbose = true, want false (flag belongs to the subcommand)")
+	}
+	if want := []string{"run", "-v", "foo.py"}; !reflect.DeepEqual(rest, want) {
+		t.Fatalf("parseGlobalFlags: rest = %v, want %v", rest, want)
+	}
+}
+
+func TestParseGlobalFlagsLongForm(t *testing.T) {
+	gf, _ := parseGlobalFlags([]string{"--dry-run", "--verbose", "run"})
+	if !gf.dryRun || !gf.verbose {
+		t.Fatalf("parseGlobalFlags: expected both flags set, got %+v", gf)
+	}
+}
+
+func TestParseGlobalFlagsShutdownGrace(t *testing.T) {
+	gf, rest := parseGlobalFlags([]string{"--shutdown-grace", "5s", "run", "foo.py"})
+	if gf.shutdownGrace != 5*time.Second {
+		t.Fatalf("parseGlobalFlags: shutdownGrace = %v, want 5s", gf.shutdownGrace)
+	}
+	if want := []string{"run", "foo.py"}; !reflect.DeepEqual(rest, want) {
+		t.Fatalf("parseGlobalFlags: rest = %v, want %v", rest, want)
+	}
+}
+
+func TestParseGlobalFlagsInvalidShutdownGraceIgnored(t *testing.T) {
+	gf, _ := parseGlobalFlags([]string{"--shutdown-grace", "not-a-duration", "run"})
+	if gf.shutdownGrace != defaultShutdownGrace {
+		t.Fatalf("parseGlobalFlags: shutdownGrace = %v, want default %v on parse failure", gf.shutdownGrace, defaultShutdownGrace)
+	}
+}
@@ -4,25 +4,183 @@ import (
 	"fmt"
 	"os"
 	"os/exec"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"time"
 )
 
 func usage() {
-	fmt.Println("gryt CLI (Go): usage\n  gryt init [path]\n  gryt run <script.py> [--parallel]\n  gryt validate <script.py>")
+	fmt.Println("gryt CLI (Go): usage\n  gryt [--python <path>] [-n|--dry-run] [-v|--verbose] [--shutdown-grace <dur>] init [path]\n  gryt [--python <path>] [-n|--dry-run] [-v|--verbose] [--shutdown-grace <dur>] run <script.py> [--parallel]\n  gryt [--python <path>] [-n|--dry-run] [-v|--verbose] validate <script.py> [--format=json] [--strict-go]")
 }
 
-func forwardToPython(args []string) int {
-	cmd := exec.Command("python", append([]string{"-m", "gryt.cli"}, args...)...)
+// defaultShutdownGrace is how long forwardToPython waits after relaying a
+// shutdown signal before escalating to SIGKILL.
+const defaultShutdownGrace = 10 * time.Second
+
+// globalFlags holds flags accepted before the subcommand.
+type globalFlags struct {
+	python        string
+	dryRun        bool
+	verbose       bool
+	shutdownGrace time.Duration
+}
+
+// parseGlobalFlags consumes global flags from the leading run of args, up to
+// the first positional argument (the subcommand). Everything from there on,
+// including the subcommand's own flags, is returned untouched in rest so a
+// `-v`/`-n`/`--python` meant for the subcommand isn't swallowed here.
+func parseGlobalFlags(args []string) (globalFlags, []string) {
+	gf := globalFlags{shutdownGrace: defaultShutdownGrace}
+	i := 0
+	for ; i < len(args); i++ {
+		switch {
+		case args[i] == "--python" && i+1 < len(args):
+			gf.python = args[i+1]
+			i++
+		case args[i] == "-n" || args[i] == "--dry-run":
+			gf.dryRun = true
+		case args[i] == "-v" || args[i] == "--verbose":
+			gf.verbose = true
+		case args[i] == "--shutdown-grace" && i+1 < len(args):
+			if d, err := time.ParseDuration(args[i+1]); err == nil {
+				gf.shutdownGrace = d
+			}
+			i++
+		default:
+			return gf, args[i:]
+		}
+	}
+	return gf, args[i:]
+}
+
+// projectMarkers are files/directories whose presence identifies the root of
+// a gryt project, checked in order of precedence.
+var projectMarkers = []string{"gryt.yaml", "gryt.toml", ".gryt", "scripts"}
+
+// findProjectRoot walks upward from start looking for a directory containing
+// one of projectMarkers, stopping at the filesystem root. It returns start
+// unchanged if no marker is ever found.
+func findProjectRoot(start string) string {
+	dir := start
+	for {
+		for _, marker := range projectMarkers {
+			if _, err := os.Stat(filepath.Join(dir, marker)); err == nil {
+				return dir
+			}
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return start
+		}
+		dir = parent
+	}
+}
+
+// resolveScriptPath resolves a possibly-relative script argument against both
+// the current working directory and the discovered project root, preferring
+// whichever one actually has the file.
+func resolveScriptPath(script, cwd, root string) string {
+	if filepath.IsAbs(script) {
+		return script
+	}
+	if _, err := os.Stat(filepath.Join(cwd, script)); err == nil {
+		return filepath.Join(cwd, script)
+	}
+	if _, err := os.Stat(filepath.Join(root, script)); err == nil {
+		return filepath.Join(root, script)
+	}
+	return script
+}
+
+// prepareRunOrValidate resolves the project root relative to cwd, rewrites
+// any relative script argument to an absolute path, and appends
+// --project-root when the discovered root differs from cwd.
+func prepareRunOrValidate(args []string, cwd string) []string {
+	root := findProjectRoot(cwd)
+	for i, a := range args {
+		if i == 0 || a == "" || a[0] == '-' {
+			continue
+		}
+		args[i] = resolveScriptPath(a, cwd, root)
+	}
+	if root != cwd {
+		rest := append([]string{}, args[1:]...)
+		args = append([]string{args[0], "--project-root", root}, rest...)
+	}
+	return args
+}
+
+// printCommandHeader echoes the fully-resolved invocation, interpreter, and
+// working directory, used by --dry-run and --verbose.
+func printCommandHeader(python string, args []string, cwd string) {
+	full := append([]string{python, "-m", "gryt.cli"}, args...)
+	fmt.Printf(">>> %s\n", strings.Join(full, " "))
+	fmt.Printf("    interpreter: %s\n", python)
+	fmt.Printf("    workdir:     %s\n", cwd)
+}
+
+func forwardToPython(python string, args []string, cwd string, gf globalFlags) int {
+	if gf.dryRun || gf.verbose {
+		printCommandHeader(python, args, cwd)
+		if gf.dryRun {
+			return 0
+		}
+	}
+	if err := checkGrytInstalled(python); err != nil {
+		fmt.Fprintf(os.Stderr, "error: %v\n", err)
+		return 1
+	}
+	cmd := exec.Command(python, append([]string{"-m", "gryt.cli"}, args...)...)
 	cmd.Stdout = os.Stdout
 	cmd.Stderr = os.Stderr
 	cmd.Stdin = os.Stdin
-	if err := cmd.Run(); err != nil {
-		if exitErr, ok := err.(*exec.ExitError); ok {
-			return exitErr.ExitCode()
-		}
+	setProcessGroup(cmd)
+
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, shutdownSignals...)
+	defer signal.Stop(sigCh)
+
+	if err := cmd.Start(); err != nil {
 		fmt.Fprintf(os.Stderr, "error: %v\n", err)
 		return 1
 	}
-	return 0
+
+	done := make(chan error, 1)
+	go func() { done <- cmd.Wait() }()
+
+	for {
+		select {
+		case err := <-done:
+			return exitCodeFromWait(cmd, err)
+		case sig := <-sigCh:
+			relaySignal(cmd, sig)
+			grace := time.NewTimer(gf.shutdownGrace)
+			select {
+			case err := <-done:
+				grace.Stop()
+				return exitCodeFromWait(cmd, err)
+			case <-grace.C:
+				killProcessGroup(cmd)
+				return exitCodeFromWait(cmd, <-done)
+			}
+		}
+	}
+}
+
+// exitCodeFromWait turns the result of cmd.Wait() into a shell-style exit
+// code, including 128+signum for signal-terminated children.
+func exitCodeFromWait(cmd *exec.Cmd, err error) int {
+	if err != nil {
+		if _, ok := err.(*exec.ExitError); !ok {
+			fmt.Fprintf(os.Stderr, "error: %v\n", err)
+			return 1
+		}
+	}
+	if cmd.ProcessState != nil {
+		return exitCodeForState(cmd.ProcessState)
+	}
+	return 1
 }
 
 func main() {
@@ -30,10 +188,45 @@ func main() {
 		usage()
 		os.Exit(1)
 	}
-	cmd := os.Args[1]
+	gf, args := parseGlobalFlags(os.Args[1:])
+	if len(args) < 1 {
+		usage()
+		os.Exit(1)
+	}
+	cmd := args[0]
 	switch cmd {
-	case "init", "run", "validate":
-		os.Exit(forwardToPython(os.Args[1:]))
+	case "run":
+		cwd, err := os.Getwd()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "error: %v\n", err)
+			os.Exit(1)
+		}
+		root := findProjectRoot(cwd)
+		python, err := resolvePython(gf.python, root)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "error: %v\n", err)
+			os.Exit(1)
+		}
+		os.Exit(forwardToPython(python, prepareRunOrValidate(args, cwd), cwd, gf))
+	case "validate":
+		cwd, err := os.Getwd()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "error: %v\n", err)
+			os.Exit(1)
+		}
+		os.Exit(runValidate(args, gf, cwd))
+	case "init":
+		cwd, err := os.Getwd()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "error: %v\n", err)
+			os.Exit(1)
+		}
+		python, err := resolvePython(gf.python, cwd)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "error: %v\n", err)
+			os.Exit(1)
+		}
+		os.Exit(forwardToPython(python, args, cwd, gf))
 	case "help", "-h", "--help":
 		usage()
 		os.Exit(0)
@@ -0,0 +1,34 @@
+//go:build !unix
+
+package main
+
+import (
+	"os"
+	"os/exec"
+)
+
+// shutdownSignals are the signals relayed to the forwarded Python process.
+var shutdownSignals = []os.Signal{os.Interrupt}
+
+// setProcessGroup is a no-op on platforms without POSIX process groups.
+func setProcessGroup(cmd *exec.Cmd) {}
+
+// relaySignal forwards sig directly to the child process; there's no process
+// group to target outside POSIX.
+func relaySignal(cmd *exec.Cmd, sig os.Signal) {
+	if cmd.Process != nil {
+		_ = cmd.Process.Signal(sig)
+	}
+}
+
+// killProcessGroup kills the child process directly.
+func killProcessGroup(cmd *exec.Cmd) {
+	if cmd.Process != nil {
+		_ = cmd.Process.Kill()
+	}
+}
+
+// exitCodeForState turns a finished process's exit state into an exit code.
+func exitCodeForState(state *os.ProcessState) int {
+	return state.ExitCode()
+}
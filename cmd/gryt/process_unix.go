@@ -0,0 +1,51 @@
+//go:build unix
+
+package main
+
+import (
+	"os"
+	"os/exec"
+	"syscall"
+)
+
+// shutdownSignals are the signals relayed to the forwarded Python process.
+var shutdownSignals = []os.Signal{syscall.SIGINT, syscall.SIGTERM, syscall.SIGHUP, syscall.SIGQUIT}
+
+// setProcessGroup puts cmd in its own process group so that signals can be
+// relayed to the whole tree of worker processes it may spawn (e.g. for
+// `gryt run --parallel`), not just the direct child.
+func setProcessGroup(cmd *exec.Cmd) {
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+}
+
+// relaySignal forwards sig to the child's process group. The negative pid is
+// the POSIX convention for "this process's group".
+func relaySignal(cmd *exec.Cmd, sig os.Signal) {
+	if cmd.Process == nil {
+		return
+	}
+	if s, ok := sig.(syscall.Signal); ok {
+		_ = syscall.Kill(-cmd.Process.Pid, s)
+	}
+}
+
+// killProcessGroup sends SIGKILL to the child's process group, used once the
+// shutdown grace period elapses.
+func killProcessGroup(cmd *exec.Cmd) {
+	if cmd.Process == nil {
+		return
+	}
+	_ = syscall.Kill(-cmd.Process.Pid, syscall.SIGKILL)
+}
+
+// exitCodeForState turns a finished process's exit state into a shell-style
+// exit code, reporting signal-terminated children as 128+signum.
+func exitCodeForState(state *os.ProcessState) int {
+	if ws, ok := state.Sys().(syscall.WaitStatus); ok {
+		if ws.Signaled() {
+			return 128 + int(ws.Signal())
+		}
+		return ws.ExitStatus()
+	}
+	return state.ExitCode()
+}
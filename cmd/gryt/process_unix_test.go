@@ -0,0 +1,42 @@
+//go:build unix
+
+package main
+
+import (
+	"os/exec"
+	"syscall"
+	"testing"
+)
+
+func TestExitCodeForStateNormalExit(t *testing.T) {
+	cmd := exec.Command("sh", "-c", "exit 7")
+	err := cmd.Run()
+	if _, ok := err.(*exec.ExitError); !ok {
+		t.Fatalf("cmd.Run: expected *exec.ExitError, got %v (%T)", err, err)
+	}
+	if got := exitCodeForState(cmd.ProcessState); got != 7 {
+		t.Fatalf("exitCodeForState = %d, want 7", got)
+	}
+}
+
+func TestExitCodeForStateSuccess(t *testing.T) {
+	cmd := exec.Command("sh", "-c", "exit 0")
+	if err := cmd.Run(); err != nil {
+		t.Fatalf("cmd.Run: unexpected error: %v", err)
+	}
+	if got := exitCodeForState(cmd.ProcessState); got != 0 {
+		t.Fatalf("exitCodeForState = %d, want 0", got)
+	}
+}
+
+func TestExitCodeForStateSignalTerminated(t *testing.T) {
+	cmd := exec.Command("sh", "-c", "kill -TERM $$; sleep 5")
+	err := cmd.Run()
+	if err == nil {
+		t.Fatal("cmd.Run: expected error for signal-terminated child")
+	}
+	want := 128 + int(syscall.SIGTERM)
+	if got := exitCodeForState(cmd.ProcessState); got != want {
+		t.Fatalf("exitCodeForState = %d, want %d (128+SIGTERM)", got, want)
+	}
+}
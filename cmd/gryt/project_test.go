@@ -0,0 +1,91 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestFindProjectRootFindsMarker(t *testing.T) {
+	root := t.TempDir()
+	if err := os.Mkdir(filepath.Join(root, "scripts"), 0o755); err != nil {
+		t.Fatalf("Mkdir: %v", err)
+	}
+	nested := filepath.Join(root, "a", "b", "c")
+	if err := os.MkdirAll(nested, 0o755); err != nil {
+		t.Fatalf("MkdirAll: %v", err)
+	}
+
+	if got := findProjectRoot(nested); got != root {
+		t.Fatalf("findProjectRoot(%q) = %q, want %q", nested, got, root)
+	}
+}
+
+func TestFindProjectRootNoMarkerReturnsStart(t *testing.T) {
+	start := t.TempDir()
+	if got := findProjectRoot(start); got != start {
+		t.Fatalf("findProjectRoot(%q) = %q, want %q (unchanged)", start, got, start)
+	}
+}
+
+func TestFindProjectRootPrefersNearestMarker(t *testing.T) {
+	root := t.TempDir()
+	if err := os.Mkdir(filepath.Join(root, "gryt.yaml"), 0o755); err != nil {
+		t.Fatalf("Mkdir: %v", err)
+	}
+	inner := filepath.Join(root, "inner")
+	if err := os.Mkdir(inner, 0o755); err != nil {
+		t.Fatalf("Mkdir: %v", err)
+	}
+	if err := os.Mkdir(filepath.Join(inner, ".gryt"), 0o755); err != nil {
+		t.Fatalf("Mkdir: %v", err)
+	}
+
+	if got := findProjectRoot(inner); got != inner {
+		t.Fatalf("findProjectRoot(%q) = %q, want %q", inner, got, inner)
+	}
+}
+
+func TestResolveScriptPathAbsolute(t *testing.T) {
+	abs := filepath.Join(t.TempDir(), "foo.py")
+	if got := resolveScriptPath(abs, "/cwd", "/root"); got != abs {
+		t.Fatalf("resolveScriptPath(%q) = %q, want unchanged %q", abs, got, abs)
+	}
+}
+
+func TestResolveScriptPathPrefersCWD(t *testing.T) {
+	cwd := t.TempDir()
+	root := t.TempDir()
+	if err := os.WriteFile(filepath.Join(cwd, "foo.py"), []byte(""), 0o644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(root, "foo.py"), []byte(""), 0o644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+
+	want := filepath.Join(cwd, "foo.py")
+	if got := resolveScriptPath("foo.py", cwd, root); got != want {
+		t.Fatalf("resolveScriptPath = %q, want %q", got, want)
+	}
+}
+
+func TestResolveScriptPathFallsBackToRoot(t *testing.T) {
+	cwd := t.TempDir()
+	root := t.TempDir()
+	if err := os.WriteFile(filepath.Join(root, "foo.py"), []byte(""), 0o644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+
+	want := filepath.Join(root, "foo.py")
+	if got := resolveScriptPath("foo.py", cwd, root); got != want {
+		t.Fatalf("resolveScriptPath = %q, want %q", got, want)
+	}
+}
+
+func TestResolveScriptPathNeitherExistsReturnsInput(t *testing.T) {
+	cwd := t.TempDir()
+	root := t.TempDir()
+	if got := resolveScriptPath("missing.py", cwd, root); got != "missing.py" {
+		t.Fatalf("resolveScriptPath = %q, want unchanged %q", got, "missing.py")
+	}
+}
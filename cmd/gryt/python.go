@@ -0,0 +1,61 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// resolvePython picks the Python interpreter to invoke, trying each source in
+// order: an explicit --python flag, the GRYT_PYTHON env var, a .gryt/python
+// file at the project root, then python3, then python on PATH.
+func resolvePython(explicit, root string) (string, error) {
+	if explicit != "" {
+		if _, err := exec.LookPath(explicit); err != nil {
+			return "", fmt.Errorf("--python %q is not executable: %w", explicit, err)
+		}
+		return explicit, nil
+	}
+	if env := os.Getenv("GRYT_PYTHON"); env != "" {
+		if _, err := exec.LookPath(env); err != nil {
+			return "", fmt.Errorf("GRYT_PYTHON=%q is not executable: %w", env, err)
+		}
+		return env, nil
+	}
+	if data, err := os.ReadFile(filepath.Join(root, ".gryt", "python")); err == nil {
+		candidate := strings.TrimSpace(string(data))
+		if candidate != "" {
+			if _, err := exec.LookPath(candidate); err != nil {
+				return "", fmt.Errorf(".gryt/python names %q, which is not executable: %w", candidate, err)
+			}
+			return candidate, nil
+		}
+	}
+	for _, candidate := range []string{"python3", "python"} {
+		if _, err := exec.LookPath(candidate); err == nil {
+			return candidate, nil
+		}
+	}
+	return "", fmt.Errorf(`no Python interpreter found on PATH
+
+gryt needs a Python interpreter with the gryt module installed. Point gryt at
+one by doing any of the following:
+  - pass --python /path/to/python
+  - set GRYT_PYTHON=/path/to/python
+  - write the path to .gryt/python in your project root
+  - install python3 and make sure it's on PATH`)
+}
+
+// checkGrytInstalled probes the resolved interpreter for the gryt module and
+// returns an actionable error if it's missing.
+func checkGrytInstalled(python string) error {
+	cmd := exec.Command(python, "-c", "import gryt")
+	cmd.Stderr = nil
+	cmd.Stdout = nil
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("%s has no 'gryt' module installed (pip install gryt) [probe: %w]", python, err)
+	}
+	return nil
+}
@@ -0,0 +1,117 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+// fakeExecutable drops an executable script named name into dir and returns
+// dir so it can be put on PATH.
+func fakeExecutable(t *testing.T, name string) string {
+	t.Helper()
+	dir := t.TempDir()
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte("#!/bin/sh\nexit 0\n"), 0o755); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+	return dir
+}
+
+func TestResolvePythonExplicitFlagTakesPriority(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("POSIX executable bit assumptions")
+	}
+	dir := fakeExecutable(t, "myinterp")
+	explicit := filepath.Join(dir, "myinterp")
+	t.Setenv("GRYT_PYTHON", "should-be-ignored")
+
+	got, err := resolvePython(explicit, t.TempDir())
+	if err != nil {
+		t.Fatalf("resolvePython: unexpected error: %v", err)
+	}
+	if got != explicit {
+		t.Fatalf("resolvePython = %q, want %q", got, explicit)
+	}
+}
+
+func TestResolvePythonExplicitFlagNotExecutable(t *testing.T) {
+	_, err := resolvePython("/no/such/interpreter", t.TempDir())
+	if err == nil {
+		t.Fatal("resolvePython: expected error for non-executable --python path")
+	}
+}
+
+func TestResolvePythonEnvVar(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("POSIX executable bit assumptions")
+	}
+	dir := fakeExecutable(t, "envinterp")
+	envPath := filepath.Join(dir, "envinterp")
+	t.Setenv("GRYT_PYTHON", envPath)
+
+	got, err := resolvePython("", t.TempDir())
+	if err != nil {
+		t.Fatalf("resolvePython: unexpected error: %v", err)
+	}
+	if got != envPath {
+		t.Fatalf("resolvePython = %q, want %q", got, envPath)
+	}
+}
+
+func TestResolvePythonGryConfigFile(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("POSIX executable bit assumptions")
+	}
+	t.Setenv("GRYT_PYTHON", "")
+	dir := fakeExecutable(t, "projectinterp")
+	configuredPath := filepath.Join(dir, "projectinterp")
+
+	root := t.TempDir()
+	if err := os.Mkdir(filepath.Join(root, ".gryt"), 0o755); err != nil {
+		t.Fatalf("Mkdir: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(root, ".gryt", "python"), []byte(configuredPath+"\n"), 0o644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+
+	got, err := resolvePython("", root)
+	if err != nil {
+		t.Fatalf("resolvePython: unexpected error: %v", err)
+	}
+	if got != configuredPath {
+		t.Fatalf("resolvePython = %q, want %q", got, configuredPath)
+	}
+}
+
+func TestResolvePythonFallsBackToPath(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("POSIX executable bit assumptions")
+	}
+	t.Setenv("GRYT_PYTHON", "")
+	dir := fakeExecutable(t, "python3")
+	t.Setenv("PATH", dir)
+
+	got, err := resolvePython("", t.TempDir())
+	if err != nil {
+		t.Fatalf("resolvePython: unexpected error: %v", err)
+	}
+	if got != "python3" {
+		t.Fatalf("resolvePython = %q, want %q", got, "python3")
+	}
+}
+
+func TestResolvePythonNoneFoundReturnsActionableError(t *testing.T) {
+	t.Setenv("GRYT_PYTHON", "")
+	t.Setenv("PATH", t.TempDir())
+
+	_, err := resolvePython("", t.TempDir())
+	if err == nil {
+		t.Fatal("resolvePython: expected error when no interpreter is on PATH")
+	}
+	if !strings.Contains(err.Error(), "--python") || !strings.Contains(err.Error(), "GRYT_PYTHON") {
+		t.Fatalf("resolvePython: error not actionable: %v", err)
+	}
+}
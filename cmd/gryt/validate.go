@@ -0,0 +1,102 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/EpykLab/gryt-ci/internal/validator"
+)
+
+// runValidate tries the native Go validator first and only shells out to
+// Python when the script uses a construct the Go validator can't statically
+// resolve (unless --strict-go forbids that fallback).
+func runValidate(args []string, gf globalFlags, cwd string) int {
+	format := "human"
+	strictGo := false
+	var script string
+	for i := 1; i < len(args); i++ {
+		switch {
+		case args[i] == "--format=json":
+			format = "json"
+		case args[i] == "--format=human":
+			format = "human"
+		case args[i] == "--strict-go":
+			strictGo = true
+		case script == "" && len(args[i]) > 0 && args[i][0] != '-':
+			script = args[i]
+		}
+	}
+	if script == "" {
+		fmt.Fprintln(os.Stderr, "error: gryt validate requires a script path")
+		return 1
+	}
+
+	root := findProjectRoot(cwd)
+	resolvedScript := resolveScriptPath(script, cwd, root)
+
+	if gf.dryRun || gf.verbose {
+		fmt.Printf(">>> validate %s\n", resolvedScript)
+		fmt.Printf("    workdir:     %s\n", cwd)
+		fmt.Printf("    format:      %s\n", format)
+		fmt.Printf("    strict-go:   %v\n", strictGo)
+		if gf.dryRun {
+			return 0
+		}
+	}
+
+	diags, err := validator.Validate(resolvedScript)
+	if err != nil {
+		if errors.Is(err, validator.ErrDynamicConstruct) {
+			if strictGo {
+				fmt.Fprintf(os.Stderr, "error: %v (--strict-go forbids falling back to Python)\n", err)
+				return 1
+			}
+			python, perr := resolvePython(gf.python, root)
+			if perr != nil {
+				fmt.Fprintf(os.Stderr, "error: %v\n", perr)
+				return 1
+			}
+			// Already printed the header above; don't let forwardToPython
+			// print it again for the fallback invocation.
+			quiet := gf
+			quiet.dryRun = false
+			quiet.verbose = false
+			return forwardToPython(python, prepareRunOrValidate(args, cwd), cwd, quiet)
+		}
+		fmt.Fprintf(os.Stderr, "error: %v\n", err)
+		return 1
+	}
+
+	if format == "json" {
+		printDiagnosticsJSON(diags)
+	} else {
+		printDiagnosticsHuman(diags)
+	}
+	for _, d := range diags {
+		if d.Severity == validator.SeverityError {
+			return 1
+		}
+	}
+	return 0
+}
+
+func printDiagnosticsHuman(diags []validator.Diagnostic) {
+	if len(diags) == 0 {
+		fmt.Println("ok")
+		return
+	}
+	for _, d := range diags {
+		fmt.Printf("%s:%d:%d: %s: %s [%s]\n", d.File, d.Line, d.Col, d.Severity, d.Message, d.Code)
+	}
+}
+
+func printDiagnosticsJSON(diags []validator.Diagnostic) {
+	if diags == nil {
+		diags = []validator.Diagnostic{}
+	}
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	_ = enc.Encode(diags)
+}
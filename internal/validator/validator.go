@@ -0,0 +1,355 @@
+// Package validator provides a fast, dependency-free check of gryt pipeline
+// scripts without shelling out to the Python interpreter. It understands the
+// subset of a script that can be resolved statically: @gryt.step decorators,
+// gryt.Pipeline(...) declarations, and an optional embedded config block.
+// Scripts that lean on dynamic constructs (eval, exec, importlib, getattr
+// dispatch, etc.) can't be resolved this way and are reported via
+// ErrDynamicConstruct so the caller can fall back to the Python validator.
+package validator
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Severity is the severity of a Diagnostic.
+type Severity string
+
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+)
+
+// Diagnostic is a single validation finding, shaped to be LSP-compatible so
+// editors can consume --format=json output directly.
+type Diagnostic struct {
+	File     string   `json:"file"`
+	Line     int      `json:"line"`
+	Col      int      `json:"col"`
+	Severity Severity `json:"severity"`
+	Message  string   `json:"message"`
+	Code     string   `json:"code"`
+}
+
+// ErrDynamicConstruct is returned (wrapped) when the script uses a construct
+// the Go validator cannot statically resolve, signalling that the caller
+// should fall back to the Python implementation unless running --strict-go.
+var ErrDynamicConstruct = fmt.Errorf("script uses a dynamic construct that requires the Python validator")
+
+var (
+	decoratorRe      = regexp.MustCompile(`^\s*@`)
+	defRe            = regexp.MustCompile(`^\s*def\s+([A-Za-z_][A-Za-z0-9_]*)\s*\(`)
+	importGrytRe     = regexp.MustCompile(`^\s*(import\s+gryt\b|from\s+gryt\b)`)
+	importAsRe       = regexp.MustCompile(`^\s*import\s+gryt\s+as\s+([A-Za-z_][A-Za-z0-9_]*)\s*$`)
+	fromImportRe     = regexp.MustCompile(`^\s*from\s+gryt\s+import\s+(.+?)\s*$`)
+	dynamicRe        = regexp.MustCompile(`\b(eval|exec|importlib|__import__|getattr)\s*\(`)
+	configStartRe    = regexp.MustCompile(`^\s*#\s*gryt:config:start\s*$`)
+	configEndRe      = regexp.MustCompile(`^\s*#\s*gryt:config:end\s*$`)
+	blankOrCommentRe = regexp.MustCompile(`^\s*(#.*)?$`)
+)
+
+// grytNames holds the local names through which the gryt package's step
+// decorator and Pipeline call can be reached in a given script, accounting
+// for `import gryt as alias` and `from gryt import step, Pipeline` forms.
+type grytNames struct {
+	pkgAliases    []string // e.g. "gryt", "g" for "import gryt"/"import gryt as g"
+	stepNames     []string // bare names usable as "@name", from "from gryt import step [as name]"
+	pipelineNames []string // bare names usable as "name(...)", from "from gryt import Pipeline [as name]"
+}
+
+// resolveGrytNames scans lines for the forms gryt can be imported under. It
+// returns an error wrapping ErrDynamicConstruct for a star import
+// (`from gryt import *`), since the names it introduces can't be resolved
+// statically.
+func resolveGrytNames(lines []string) (*grytNames, error) {
+	names := &grytNames{pkgAliases: []string{"gryt"}}
+	for i, line := range lines {
+		if m := importAsRe.FindStringSubmatch(line); m != nil {
+			names.pkgAliases = append(names.pkgAliases, m[1])
+			continue
+		}
+		m := fromImportRe.FindStringSubmatch(line)
+		if m == nil {
+			continue
+		}
+		if m[1] == "*" {
+			return nil, fmt.Errorf("line %d: %w", i+1, ErrDynamicConstruct)
+		}
+		for _, item := range strings.Split(m[1], ",") {
+			fields := strings.Fields(strings.TrimSpace(item))
+			if len(fields) == 0 {
+				continue
+			}
+			local := fields[0]
+			if len(fields) == 3 && fields[1] == "as" {
+				local = fields[2]
+			}
+			switch fields[0] {
+			case "step":
+				names.stepNames = append(names.stepNames, local)
+			case "Pipeline":
+				names.pipelineNames = append(names.pipelineNames, local)
+			}
+		}
+	}
+	return names, nil
+}
+
+// stepDecoratorPattern returns a regexp matching a step decorator line under
+// any of the resolved names (e.g. "@gryt.step", "@g.step", or bare "@step"
+// for "from gryt import step").
+func (n *grytNames) stepDecoratorPattern() *regexp.Regexp {
+	var alts []string
+	for _, alias := range n.pkgAliases {
+		alts = append(alts, regexp.QuoteMeta(alias)+`\.step`)
+	}
+	for _, name := range n.stepNames {
+		alts = append(alts, regexp.QuoteMeta(name))
+	}
+	return regexp.MustCompile(`^\s*@(` + strings.Join(alts, "|") + `)(\(.*\))?\s*$`)
+}
+
+// pipelinePattern returns a regexp matching a Pipeline(...) call under any of
+// the resolved names (e.g. "gryt.Pipeline(", "g.Pipeline(", or bare
+// "Pipeline(" for "from gryt import Pipeline").
+func (n *grytNames) pipelinePattern() *regexp.Regexp {
+	var alts []string
+	for _, alias := range n.pkgAliases {
+		alts = append(alts, regexp.QuoteMeta(alias)+`\.Pipeline`)
+	}
+	for _, name := range n.pipelineNames {
+		alts = append(alts, regexp.QuoteMeta(name))
+	}
+	return regexp.MustCompile(`(` + strings.Join(alts, "|") + `)\s*\(`)
+}
+
+// configSchema is the minimal required shape of an embedded config block.
+// It's intentionally small: a name and a version, both strings.
+type configSchema struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+// Validate reads the script at path and returns diagnostics describing any
+// schema violations. It returns an error wrapping ErrDynamicConstruct if the
+// script contains constructs the Go validator can't statically resolve.
+func Validate(path string) ([]Diagnostic, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("validator: %w", err)
+	}
+	defer f.Close()
+
+	var lines []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		lines = append(lines, scanner.Text())
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("validator: %s: %w", path, err)
+	}
+
+	names, err := resolveGrytNames(lines)
+	if err != nil {
+		return nil, fmt.Errorf("validator: %s: %w", path, err)
+	}
+	stepDecoratorRe := names.stepDecoratorPattern()
+	pipelineRe := names.pipelinePattern()
+
+	var diags []Diagnostic
+	var (
+		hasImport     bool
+		hasPipeline   bool
+		pendingStep   bool
+		stepLines     = map[string]int{}
+		pipelineBody  strings.Builder
+		inPipeline    bool
+		pipelineDepth int
+		inConfig      bool
+		configLines   []string
+		configLine    int
+	)
+
+	for i, line := range lines {
+		lineNo := i + 1
+
+		if dynamicRe.MatchString(line) {
+			return diags, fmt.Errorf("validator: %s:%d: %w", path, lineNo, ErrDynamicConstruct)
+		}
+		if importGrytRe.MatchString(line) {
+			hasImport = true
+		}
+		if configStartRe.MatchString(line) {
+			inConfig = true
+			configLine = lineNo + 1
+			continue
+		}
+		if configEndRe.MatchString(line) {
+			inConfig = false
+			if d := validateConfigBlock(path, configLine, strings.Join(configLines, "\n")); d != nil {
+				diags = append(diags, *d)
+			}
+			configLines = nil
+			continue
+		}
+		if inConfig {
+			configLines = append(configLines, strings.TrimPrefix(strings.TrimPrefix(strings.TrimSpace(line), "#"), " "))
+			continue
+		}
+
+		if stepDecoratorRe.MatchString(line) {
+			pendingStep = true
+			continue
+		}
+		if m := defRe.FindStringSubmatch(line); m != nil {
+			name := m[1]
+			if pendingStep {
+				if prev, ok := stepLines[name]; ok {
+					diags = append(diags, Diagnostic{
+						File: path, Line: lineNo, Col: 1, Severity: SeverityError,
+						Message: fmt.Sprintf("duplicate step name %q (first defined on line %d)", name, prev),
+						Code:    "GRYT002",
+					})
+				} else {
+					stepLines[name] = lineNo
+				}
+			}
+			pendingStep = false
+			continue
+		}
+		if decoratorRe.MatchString(line) {
+			// Another decorator stacked above/below @gryt.step (e.g.
+			// @functools.wraps(...)) - keep waiting for the def line.
+			continue
+		}
+		if pendingStep && blankOrCommentRe.MatchString(line) {
+			// A blank line or a plain comment between @gryt.step and its
+			// def is valid Python - keep waiting instead of dropping the
+			// pending step.
+			continue
+		}
+		pendingStep = false
+
+		if pipelineRe.MatchString(line) {
+			hasPipeline = true
+			inPipeline = true
+			pipelineDepth = 0
+		}
+		if inPipeline {
+			pipelineBody.WriteString(line)
+			pipelineBody.WriteString("\n")
+			pipelineDepth += parenDelta(line)
+			if pipelineDepth <= 0 {
+				inPipeline = false
+			}
+		}
+	}
+	if inPipeline {
+		// The Pipeline(...) call never closed within the lines we scanned -
+		// could be a genuinely unterminated call or a paren-counting edge
+		// case we can't resolve statically (e.g. a triple-quoted string
+		// spanning lines). Don't risk a wrong "unreachable step" report.
+		return diags, fmt.Errorf("validator: %s: unterminated gryt.Pipeline(...) call: %w", path, ErrDynamicConstruct)
+	}
+
+	if !hasImport {
+		diags = append(diags, Diagnostic{
+			File: path, Line: 1, Col: 1, Severity: SeverityError,
+			Message: "script does not import the gryt module",
+			Code:    "GRYT001",
+		})
+	}
+	if !hasPipeline {
+		diags = append(diags, Diagnostic{
+			File: path, Line: 1, Col: 1, Severity: SeverityError,
+			Message: "script does not declare a gryt.Pipeline(...)",
+			Code:    "GRYT003",
+		})
+	}
+	for _, d := range unreachableSteps(path, stepLines, pipelineBody.String()) {
+		diags = append(diags, d)
+	}
+
+	return diags, nil
+}
+
+// parenDelta returns the net change in paren depth contributed by line,
+// ignoring parens that appear inside single- or double-quoted string
+// literals (e.g. name="build)"). It assumes the string doesn't span lines.
+func parenDelta(line string) int {
+	delta := 0
+	var quote byte
+	for i := 0; i < len(line); i++ {
+		c := line[i]
+		if quote != 0 {
+			if c == '\\' {
+				i++
+				continue
+			}
+			if c == quote {
+				quote = 0
+			}
+			continue
+		}
+		switch c {
+		case '\'', '"':
+			quote = c
+		case '(':
+			delta++
+		case ')':
+			delta--
+		}
+	}
+	return delta
+}
+
+// unreachableSteps reports @gryt.step functions that are never named inside
+// the Pipeline(...) declaration, i.e. steps that can never run. Diagnostics
+// are returned in line order so --format=json output is deterministic.
+func unreachableSteps(path string, steps map[string]int, pipelineBody string) []Diagnostic {
+	var diags []Diagnostic
+	for name, line := range steps {
+		if !strings.Contains(pipelineBody, name) {
+			diags = append(diags, Diagnostic{
+				File: path, Line: line, Col: 1, Severity: SeverityWarning,
+				Message: fmt.Sprintf("step %q is never referenced by a Pipeline and is unreachable", name),
+				Code:    "GRYT004",
+			})
+		}
+	}
+	sort.Slice(diags, func(i, j int) bool { return diags[i].Line < diags[j].Line })
+	return diags
+}
+
+// validateConfigBlock parses the JSON body of a `# gryt:config:start` /
+// `# gryt:config:end` block and checks it against configSchema.
+func validateConfigBlock(path string, startLine int, body string) *Diagnostic {
+	var cfg configSchema
+	if err := json.Unmarshal([]byte(body), &cfg); err != nil {
+		return &Diagnostic{
+			File: path, Line: startLine, Col: 1, Severity: SeverityError,
+			Message: fmt.Sprintf("embedded config block is not valid JSON: %v", err),
+			Code:    "GRYT005",
+		}
+	}
+	if cfg.Name == "" {
+		return &Diagnostic{
+			File: path, Line: startLine, Col: 1, Severity: SeverityError,
+			Message: "embedded config block is missing required field \"name\"",
+			Code:    "GRYT005",
+		}
+	}
+	if cfg.Version == "" {
+		return &Diagnostic{
+			File: path, Line: startLine, Col: 1, Severity: SeverityError,
+			Message: "embedded config block is missing required field \"version\"",
+			Code:    "GRYT005",
+		}
+	}
+	return nil
+}
@@ -0,0 +1,342 @@
+package validator
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeScript(t *testing.T, body string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "pipeline.py")
+	if err := os.WriteFile(path, []byte(body), 0o644); err != nil {
+		t.Fatalf("writeScript: %v", err)
+	}
+	return path
+}
+
+func codes(diags []Diagnostic) []string {
+	out := make([]string, len(diags))
+	for i, d := range diags {
+		out[i] = d.Code
+	}
+	return out
+}
+
+func TestValidateCleanScript(t *testing.T) {
+	path := writeScript(t, `import gryt
+
+@gryt.step
+def step_one():
+    pass
+
+gryt.Pipeline(steps=[step_one])
+`)
+	diags, err := Validate(path)
+	if err != nil {
+		t.Fatalf("Validate: unexpected error: %v", err)
+	}
+	if len(diags) != 0 {
+		t.Fatalf("Validate: expected no diagnostics, got %+v", diags)
+	}
+}
+
+func TestValidateMissingImport(t *testing.T) {
+	path := writeScript(t, `gryt.Pipeline(steps=[])
+`)
+	diags, err := Validate(path)
+	if err != nil {
+		t.Fatalf("Validate: unexpected error: %v", err)
+	}
+	if got := codes(diags); len(got) != 1 || got[0] != "GRYT001" {
+		t.Fatalf("Validate: expected [GRYT001], got %v", got)
+	}
+}
+
+func TestValidateMissingPipeline(t *testing.T) {
+	path := writeScript(t, `import gryt
+
+@gryt.step
+def step_one():
+    pass
+`)
+	diags, err := Validate(path)
+	if err != nil {
+		t.Fatalf("Validate: unexpected error: %v", err)
+	}
+	// With no Pipeline(...) at all, step_one is also correctly reported as
+	// unreachable alongside the missing-pipeline error.
+	if got := codes(diags); len(got) != 2 || got[0] != "GRYT003" || got[1] != "GRYT004" {
+		t.Fatalf("Validate: expected [GRYT003 GRYT004], got %v", got)
+	}
+}
+
+func TestValidateDuplicateStep(t *testing.T) {
+	path := writeScript(t, `import gryt
+
+@gryt.step
+def step_one():
+    pass
+
+@gryt.step
+def step_one():
+    pass
+
+gryt.Pipeline(steps=[step_one])
+`)
+	diags, err := Validate(path)
+	if err != nil {
+		t.Fatalf("Validate: unexpected error: %v", err)
+	}
+	if got := codes(diags); len(got) != 1 || got[0] != "GRYT002" {
+		t.Fatalf("Validate: expected [GRYT002], got %v", got)
+	}
+}
+
+func TestValidateUnreachableStep(t *testing.T) {
+	path := writeScript(t, `import gryt
+
+@gryt.step
+def step_one():
+    pass
+
+@gryt.step
+def step_two():
+    pass
+
+gryt.Pipeline(steps=[step_one])
+`)
+	diags, err := Validate(path)
+	if err != nil {
+		t.Fatalf("Validate: unexpected error: %v", err)
+	}
+	if got := codes(diags); len(got) != 1 || got[0] != "GRYT004" {
+		t.Fatalf("Validate: expected [GRYT004], got %v", got)
+	}
+	if diags[0].Severity != SeverityWarning {
+		t.Fatalf("Validate: expected GRYT004 to be a warning, got %s", diags[0].Severity)
+	}
+}
+
+// TestValidateMultiLinePipelineWithParenInString guards against a regression
+// where a ')' inside a string literal (e.g. name="build)") was mistaken for
+// the end of the Pipeline(...) call, truncating pipelineBody before the
+// referenced steps and producing spurious GRYT004 diagnostics.
+func TestValidateMultiLinePipelineWithParenInString(t *testing.T) {
+	path := writeScript(t, `import gryt
+
+@gryt.step
+def step_one():
+    pass
+
+@gryt.step
+def step_two():
+    pass
+
+gryt.Pipeline(
+    name="build)",
+    steps=[step_one, step_two],
+)
+`)
+	diags, err := Validate(path)
+	if err != nil {
+		t.Fatalf("Validate: unexpected error: %v", err)
+	}
+	if len(diags) != 0 {
+		t.Fatalf("Validate: expected no diagnostics, got %+v", diags)
+	}
+}
+
+func TestValidateUnterminatedPipelineFallsBack(t *testing.T) {
+	path := writeScript(t, `import gryt
+
+@gryt.step
+def step_one():
+    pass
+
+gryt.Pipeline(
+    steps=[step_one],
+`)
+	_, err := Validate(path)
+	if !errors.Is(err, ErrDynamicConstruct) {
+		t.Fatalf("Validate: expected ErrDynamicConstruct for unterminated Pipeline(...), got %v", err)
+	}
+}
+
+func TestValidateDynamicConstructFallsBack(t *testing.T) {
+	path := writeScript(t, `import gryt
+
+step = getattr(gryt, "step")
+
+gryt.Pipeline(steps=[])
+`)
+	_, err := Validate(path)
+	if !errors.Is(err, ErrDynamicConstruct) {
+		t.Fatalf("Validate: expected ErrDynamicConstruct, got %v", err)
+	}
+}
+
+func TestValidateConfigBlockValid(t *testing.T) {
+	path := writeScript(t, `import gryt
+
+# gryt:config:start
+# {"name": "build", "version": "1.0"}
+# gryt:config:end
+
+gryt.Pipeline(steps=[])
+`)
+	diags, err := Validate(path)
+	if err != nil {
+		t.Fatalf("Validate: unexpected error: %v", err)
+	}
+	if len(diags) != 0 {
+		t.Fatalf("Validate: expected no diagnostics, got %+v", diags)
+	}
+}
+
+func TestValidateConfigBlockMissingField(t *testing.T) {
+	path := writeScript(t, `import gryt
+
+# gryt:config:start
+# {"name": "build"}
+# gryt:config:end
+
+gryt.Pipeline(steps=[])
+`)
+	diags, err := Validate(path)
+	if err != nil {
+		t.Fatalf("Validate: unexpected error: %v", err)
+	}
+	if got := codes(diags); len(got) != 1 || got[0] != "GRYT005" {
+		t.Fatalf("Validate: expected [GRYT005], got %v", got)
+	}
+}
+
+// TestValidateConfigBlockIndented guards against a regression where an
+// embedded config block nested inside an if/def/class body kept its leading
+// whitespace in the extracted JSON text and failed to parse.
+func TestValidateConfigBlockIndented(t *testing.T) {
+	path := writeScript(t, `import gryt
+
+if True:
+    # gryt:config:start
+    # {"name": "build", "version": "1.0"}
+    # gryt:config:end
+
+gryt.Pipeline(steps=[])
+`)
+	diags, err := Validate(path)
+	if err != nil {
+		t.Fatalf("Validate: unexpected error: %v", err)
+	}
+	if len(diags) != 0 {
+		t.Fatalf("Validate: expected no diagnostics, got %+v", diags)
+	}
+}
+
+// TestValidateStackedDecorators guards against a regression where another
+// decorator stacked between @gryt.step and def (e.g. @functools.wraps(...))
+// reset the pending-step state, silently dropping the step from
+// consideration instead of flagging it as unreachable.
+func TestValidateStackedDecorators(t *testing.T) {
+	path := writeScript(t, `import gryt
+
+@gryt.step
+@functools.wraps(step_one)
+def step_one():
+    pass
+
+gryt.Pipeline(steps=[])
+`)
+	diags, err := Validate(path)
+	if err != nil {
+		t.Fatalf("Validate: unexpected error: %v", err)
+	}
+	if got := codes(diags); len(got) != 1 || got[0] != "GRYT004" {
+		t.Fatalf("Validate: expected [GRYT004], got %v", got)
+	}
+}
+
+// TestValidateCommentBetweenDecoratorAndDef guards against a regression
+// where a comment line between @gryt.step and its def - valid Python -
+// reset the pending-step state, silently dropping the step from duplicate
+// and unreachable tracking.
+func TestValidateCommentBetweenDecoratorAndDef(t *testing.T) {
+	path := writeScript(t, `import gryt
+
+@gryt.step
+# explains why step_one exists
+def step_one():
+    pass
+
+gryt.Pipeline(steps=[])
+`)
+	diags, err := Validate(path)
+	if err != nil {
+		t.Fatalf("Validate: unexpected error: %v", err)
+	}
+	if got := codes(diags); len(got) != 1 || got[0] != "GRYT004" {
+		t.Fatalf("Validate: expected [GRYT004], got %v", got)
+	}
+}
+
+// TestValidateImportAlias guards against a regression where `import gryt as
+// g` caused @g.step and g.Pipeline(...) to go undetected, wrongly emitting
+// GRYT001/GRYT003 for a script that does import and use gryt correctly.
+func TestValidateImportAlias(t *testing.T) {
+	path := writeScript(t, `import gryt as g
+
+@g.step
+def step_one():
+    pass
+
+g.Pipeline(steps=[step_one])
+`)
+	diags, err := Validate(path)
+	if err != nil {
+		t.Fatalf("Validate: unexpected error: %v", err)
+	}
+	if len(diags) != 0 {
+		t.Fatalf("Validate: expected no diagnostics, got %+v", diags)
+	}
+}
+
+// TestValidateFromImportBareNames guards against a regression where `from
+// gryt import step, Pipeline` caused bare @step and Pipeline(...) usage to go
+// undetected, wrongly emitting GRYT001/GRYT003.
+func TestValidateFromImportBareNames(t *testing.T) {
+	path := writeScript(t, `from gryt import step, Pipeline
+
+@step
+def step_one():
+    pass
+
+Pipeline(steps=[step_one])
+`)
+	diags, err := Validate(path)
+	if err != nil {
+		t.Fatalf("Validate: unexpected error: %v", err)
+	}
+	if len(diags) != 0 {
+		t.Fatalf("Validate: expected no diagnostics, got %+v", diags)
+	}
+}
+
+// TestValidateFromImportStarFallsBack ensures a star import, whose names
+// can't be resolved statically, triggers the Python fallback rather than a
+// hard GRYT001/GRYT003 error.
+func TestValidateFromImportStarFallsBack(t *testing.T) {
+	path := writeScript(t, `from gryt import *
+
+@step
+def step_one():
+    pass
+
+Pipeline(steps=[step_one])
+`)
+	_, err := Validate(path)
+	if !errors.Is(err, ErrDynamicConstruct) {
+		t.Fatalf("Validate: expected ErrDynamicConstruct for star import, got %v", err)
+	}
+}